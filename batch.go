@@ -0,0 +1,139 @@
+package randflake
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// reserveBatch reserves up to count contiguous sequence numbers with a
+// single atomic add, amortizing the per-ID atomic.Add and clock read that
+// Generate pays individually. It returns the first reserved sequence number,
+// how many of the count reservations are actually usable for timestamp
+// (n may be less than count if the batch crossed the per-second sequence
+// limit), and the raw Unix timestamp they were reserved under.
+//
+// If the batch only partially fits before the sequence space for this second
+// is exhausted, n < count is returned along with ErrResourceExhausted; the
+// caller should emit the first n IDs and retry for the rest.
+//
+// When the exhausted batch is the one that triggers the rollover, the retry
+// starts the new second's counter from sequence 1, not 0: unlike newRAW
+// (which resets the counter and immediately returns sequence 0 to the same
+// caller that triggered the reset), reserveBatch discards the rolled-over
+// tail of the current batch rather than reissuing it against the new
+// second's timestamp. This wastes exactly one sequence number per rollover,
+// which is cheap relative to the 131072-per-second budget and keeps a batch
+// tied to a single timestamp instead of splitting it across two.
+func (g *Generator) reserveBatch(count int) (start int64, n int, timestamp int64, err error) {
+	var now int64
+	if g.TimeSource != nil {
+		now = g.TimeSource()
+	} else {
+		now = time.Now().Unix()
+	}
+
+	if now < g.leaseStart {
+		return 0, 0, 0, ErrInvalidLease
+	}
+	if now > g.leaseEnd.Load() {
+		return 0, 0, 0, ErrInvalidLease
+	}
+
+	end := g.sequence.Add(int64(count))
+	start = end - int64(count) + 1
+	n = count
+
+	if end > RANDFLAKE_MAX_SEQUENCE {
+		if start > RANDFLAKE_MAX_SEQUENCE {
+			n = 0
+		} else {
+			n = int(RANDFLAKE_MAX_SEQUENCE - start + 1)
+		}
+
+		last := g.rollover.Load()
+		switch {
+		case now > last:
+			if g.rollover.CompareAndSwap(last, now) {
+				g.sequence.Store(0)
+			}
+			err = ErrResourceExhausted
+		case now < last:
+			return 0, 0, 0, ErrConsistencyViolation
+		default:
+			err = ErrResourceExhausted
+		}
+	}
+
+	return start, n, now - RANDFLAKE_EPOCH_OFFSET, err
+}
+
+// GenerateBatch reserves a contiguous run of sequence numbers and encrypts
+// len(dst) (or fewer, see below) IDs into dst in a single tight loop,
+// amortizing the atomic increment and clock read Generate otherwise pays per
+// ID. It returns the number of IDs written to dst[:n]. If the batch crosses
+// the per-second sequence limit, n may be less than len(dst); the returned
+// error is ErrResourceExhausted in that case and the caller should retry for
+// the remainder.
+func (g *Generator) GenerateBatch(dst []int64) (n int, err error) {
+	if len(dst) == 0 {
+		return 0, nil
+	}
+
+	start, n, timestamp, err := g.reserveBatch(len(dst))
+	if n == 0 {
+		return 0, err
+	}
+
+	nodeID := g.nodeID
+	buf := make([]byte, n*8)
+	for i := 0; i < n; i++ {
+		raw := uint64((timestamp << (RANDFLAKE_NODE_BITS + RANDFLAKE_SEQUENCE_BITS)) |
+			(nodeID << RANDFLAKE_SEQUENCE_BITS) |
+			(start + int64(i)))
+		binary.LittleEndian.PutUint64(buf[i*8:i*8+8], raw)
+	}
+
+	if encErr := g.sbox.EncryptBlocks(buf, buf); encErr != nil {
+		return 0, encErr
+	}
+
+	for i := 0; i < n; i++ {
+		dst[i] = int64(binary.LittleEndian.Uint64(buf[i*8 : i*8+8]))
+	}
+
+	return n, err
+}
+
+// GenerateStringBatch is GenerateBatch followed by base32hex-encoding each
+// ID, reusing a single scratch buffer across the batch instead of allocating
+// one per ID.
+func (g *Generator) GenerateStringBatch(dst []string) (n int, err error) {
+	if len(dst) == 0 {
+		return 0, nil
+	}
+
+	start, n, timestamp, err := g.reserveBatch(len(dst))
+	if n == 0 {
+		return 0, err
+	}
+
+	nodeID := g.nodeID
+	buf := make([]byte, n*8)
+	for i := 0; i < n; i++ {
+		raw := uint64((timestamp << (RANDFLAKE_NODE_BITS + RANDFLAKE_SEQUENCE_BITS)) |
+			(nodeID << RANDFLAKE_SEQUENCE_BITS) |
+			(start + int64(i)))
+		binary.LittleEndian.PutUint64(buf[i*8:i*8+8], raw)
+	}
+
+	if encErr := g.sbox.EncryptBlocks(buf, buf); encErr != nil {
+		return 0, encErr
+	}
+
+	var encBuf [13]byte
+	for i := 0; i < n; i++ {
+		dst[i] = base32hexencodeInto(encBuf[:], binary.LittleEndian.Uint64(buf[i*8:i*8+8]))
+	}
+
+	return n, err
+}
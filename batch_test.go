@@ -0,0 +1,91 @@
+package randflake
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenerator_GenerateBatch(t *testing.T) {
+	secret := make([]byte, 16)
+	now := time.Now().Unix()
+
+	g, err := NewGenerator(1, now-1, now+3600, secret)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	g.TimeSource = func() int64 { return now }
+
+	dst := make([]int64, 1000)
+	n, err := g.GenerateBatch(dst)
+	if err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+	if n != len(dst) {
+		t.Fatalf("expected %d IDs, got %d", len(dst), n)
+	}
+
+	seen := make(map[int64]bool, n)
+	for i := 0; i < n; i++ {
+		if seen[dst[i]] {
+			t.Errorf("GenerateBatch produced duplicate ID: %d", dst[i])
+		}
+		seen[dst[i]] = true
+	}
+}
+
+func TestGenerator_GenerateBatch_SequenceOverflow(t *testing.T) {
+	secret := make([]byte, 16)
+	now := time.Now().Unix()
+
+	g, err := NewGenerator(1, now-1, now+3600, secret)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	g.TimeSource = func() int64 { return now }
+
+	dst := make([]int64, RANDFLAKE_MAX_SEQUENCE+100)
+	n, err := g.GenerateBatch(dst)
+	if err != ErrResourceExhausted {
+		t.Fatalf("expected ErrResourceExhausted, got %v", err)
+	}
+	if n != RANDFLAKE_MAX_SEQUENCE {
+		t.Fatalf("expected %d IDs before overflow, got %d", RANDFLAKE_MAX_SEQUENCE, n)
+	}
+}
+
+func TestGenerator_GenerateStringBatch(t *testing.T) {
+	secret := make([]byte, 16)
+	now := time.Now().Unix()
+
+	g, err := NewGenerator(1, now-1, now+3600, secret)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	g.TimeSource = func() int64 { return now }
+
+	ids := make([]int64, 100)
+	if _, err := g.GenerateBatch(ids); err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+
+	g2, err := NewGenerator(1, now-1, now+3600, secret)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	g2.TimeSource = func() int64 { return now }
+
+	strs := make([]string, 100)
+	n, err := g2.GenerateStringBatch(strs)
+	if err != nil {
+		t.Fatalf("GenerateStringBatch failed: %v", err)
+	}
+	if n != len(strs) {
+		t.Fatalf("expected %d strings, got %d", len(strs), n)
+	}
+
+	for i, s := range strs {
+		if s != EncodeString(ids[i]) {
+			t.Errorf("GenerateStringBatch[%d] = %q, want %q", i, s, EncodeString(ids[i]))
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package randflake
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+)
+
+// Encoding converts a randflake ID between its numeric form and a string
+// representation suitable for external systems.
+type Encoding interface {
+	Encode(id uint64) string
+	Decode(s string) (uint64, error)
+}
+
+type base32HexEncoding struct{}
+
+func (base32HexEncoding) Encode(id uint64) string         { return base32hexencode(id) }
+func (base32HexEncoding) Decode(s string) (uint64, error) { return base32hexdecode(s) }
+
+// Base32HexEncoding is the package's original RFC 4648 base32hex Encoding,
+// matching GenerateString/InspectString.
+var Base32HexEncoding Encoding = base32HexEncoding{}
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// crockfordCheckAlphabet extends crockfordAlphabet with the five extra
+// symbols Crockford's Base32 spec reserves for the optional check symbol.
+const crockfordCheckAlphabet = crockfordAlphabet + "*~$=U"
+
+type crockfordEncoding struct {
+	withCheck bool
+}
+
+// NewCrockfordEncoding returns a Crockford Base32 Encoding. Crockford's
+// alphabet excludes the visually confusable letters I, L, O, and U, decodes
+// case-insensitively, and treats O as 0 and I/L as 1 to tolerate common
+// transcription mistakes. If withCheck is true, Encode appends a mod-37
+// check symbol and Decode verifies it.
+func NewCrockfordEncoding(withCheck bool) Encoding {
+	return crockfordEncoding{withCheck: withCheck}
+}
+
+// CrockfordEncoding and CrockfordCheckedEncoding are ready-to-use Crockford
+// Base32 encodings, without and with a trailing check symbol.
+var (
+	CrockfordEncoding        Encoding = NewCrockfordEncoding(false)
+	CrockfordCheckedEncoding Encoding = NewCrockfordEncoding(true)
+)
+
+func (e crockfordEncoding) Encode(id uint64) string {
+	s := encodeBase(id, crockfordAlphabet)
+	if !e.withCheck {
+		return s
+	}
+	return s + string(crockfordCheckAlphabet[id%37])
+}
+
+func (e crockfordEncoding) Decode(s string) (uint64, error) {
+	if e.withCheck {
+		if len(s) == 0 {
+			return 0, ErrInvalidID
+		}
+		body, check := s[:len(s)-1], s[len(s)-1:]
+		id, err := decodeBase(crockfordNormalize(body), crockfordAlphabet)
+		if err != nil {
+			return 0, err
+		}
+		if strings.ToUpper(check) != string(crockfordCheckAlphabet[id%37]) {
+			return 0, ErrInvalidID
+		}
+		return id, nil
+	}
+	return decodeBase(crockfordNormalize(s), crockfordAlphabet)
+}
+
+// crockfordNormalize upper-cases s and maps the visually confusable letters
+// Crockford's spec warns about onto their canonical digit.
+func crockfordNormalize(s string) string {
+	b := []byte(strings.ToUpper(s))
+	for i, c := range b {
+		switch c {
+		case 'O':
+			b[i] = '0'
+		case 'I', 'L':
+			b[i] = '1'
+		}
+	}
+	return string(b)
+}
+
+// base58Alphabet is the Bitcoin Base58 alphabet (no 0, O, I, or l).
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+type base58Encoding struct{}
+
+func (base58Encoding) Encode(id uint64) string         { return encodeBase(id, base58Alphabet) }
+func (base58Encoding) Decode(s string) (uint64, error) { return decodeBase(s, base58Alphabet) }
+
+// Base58Encoding encodes IDs using the Bitcoin Base58 alphabet.
+var Base58Encoding Encoding = base58Encoding{}
+
+type base64Encoding struct{}
+
+func (base64Encoding) Encode(id uint64) string {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], id)
+	return base64.RawURLEncoding.EncodeToString(b[:])
+}
+
+func (base64Encoding) Decode(s string) (uint64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) != 8 {
+		return 0, ErrInvalidID
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// Base64Encoding encodes IDs as 8 raw bytes in URL-safe, unpadded Base64.
+var Base64Encoding Encoding = base64Encoding{}
+
+// encodeBase encodes num in the given alphabet, most-significant digit
+// first, the same way base32hexencode does for its fixed alphabet.
+func encodeBase(num uint64, alphabet string) string {
+	if num == 0 {
+		return string(alphabet[0])
+	}
+
+	var buf [64]byte
+	idx := len(buf)
+	base := uint64(len(alphabet))
+	for num > 0 {
+		idx--
+		buf[idx] = alphabet[num%base]
+		num /= base
+	}
+	return string(buf[idx:])
+}
+
+// decodeBase is the inverse of encodeBase.
+func decodeBase(s string, alphabet string) (uint64, error) {
+	if len(s) == 0 {
+		return 0, ErrInvalidID
+	}
+
+	var num uint64
+	base := uint64(len(alphabet))
+	for i := 0; i < len(s); i++ {
+		idx := strings.IndexByte(alphabet, s[i])
+		if idx < 0 {
+			return 0, ErrInvalidID
+		}
+		num = num*base + uint64(idx)
+	}
+	return num, nil
+}
+
+// GenerateWithEncoding generates a unique, encrypted ID and returns it
+// encoded with enc.
+func (g *Generator) GenerateWithEncoding(enc Encoding) (string, error) {
+	id, err := g.Generate()
+	if err != nil {
+		return "", err
+	}
+	return enc.Encode(uint64(id)), nil
+}
+
+// InspectWithEncoding decodes s using enc and inspects the resulting ID.
+func (g *Generator) InspectWithEncoding(enc Encoding, s string) (timestamp int64, nodeID int64, sequence int64, err error) {
+	num, err := enc.Decode(s)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return g.Inspect(int64(num))
+}
@@ -0,0 +1,142 @@
+package randflake
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCrockfordEncodingRoundTrip(t *testing.T) {
+	values := []uint64{0, 1, 42, 131071, 1 << 40, ^uint64(0)}
+
+	for _, v := range values {
+		s := CrockfordEncoding.Encode(v)
+		got, err := CrockfordEncoding.Decode(s)
+		if err != nil {
+			t.Fatalf("Decode(%q) failed: %v", s, err)
+		}
+		if got != v {
+			t.Errorf("Crockford round trip: encoded %d as %q, decoded back to %d", v, s, got)
+		}
+
+		if got, err := CrockfordEncoding.Decode(crockfordLower(s)); err != nil || got != v {
+			t.Errorf("Crockford decode is not case-insensitive for %q: got %d, %v", s, got, err)
+		}
+	}
+}
+
+func crockfordLower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c - 'A' + 'a'
+		}
+	}
+	return string(b)
+}
+
+func TestCrockfordCheckedEncoding(t *testing.T) {
+	id := uint64(123456789)
+	s := CrockfordCheckedEncoding.Encode(id)
+
+	got, err := CrockfordCheckedEncoding.Decode(s)
+	if err != nil {
+		t.Fatalf("Decode(%q) failed: %v", s, err)
+	}
+	if got != id {
+		t.Errorf("expected %d, got %d", id, got)
+	}
+
+	tampered := []byte(s)
+	tampered[len(tampered)-1] = '9'
+	if tampered[len(tampered)-1] == s[len(s)-1] {
+		tampered[len(tampered)-1] = '8'
+	}
+	if _, err := CrockfordCheckedEncoding.Decode(string(tampered)); err == nil {
+		t.Error("expected an error when the check symbol is wrong")
+	}
+}
+
+func TestBase58EncodingRoundTrip(t *testing.T) {
+	values := []uint64{0, 1, 58, 1000000, ^uint64(0)}
+
+	for _, v := range values {
+		s := Base58Encoding.Encode(v)
+		got, err := Base58Encoding.Decode(s)
+		if err != nil {
+			t.Fatalf("Decode(%q) failed: %v", s, err)
+		}
+		if got != v {
+			t.Errorf("Base58 round trip: encoded %d as %q, decoded back to %d", v, s, got)
+		}
+	}
+}
+
+func TestBase64EncodingRoundTrip(t *testing.T) {
+	values := []uint64{0, 1, 1 << 32, ^uint64(0)}
+
+	for _, v := range values {
+		s := Base64Encoding.Encode(v)
+		got, err := Base64Encoding.Decode(s)
+		if err != nil {
+			t.Fatalf("Decode(%q) failed: %v", s, err)
+		}
+		if got != v {
+			t.Errorf("Base64 round trip: encoded %d as %q, decoded back to %d", v, s, got)
+		}
+	}
+}
+
+func TestGenerator_GenerateWithEncoding(t *testing.T) {
+	secret := make([]byte, 16)
+	now := time.Now().Unix()
+
+	g, err := NewGenerator(1, now-1, now+3600, secret)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	s, err := g.GenerateWithEncoding(Base58Encoding)
+	if err != nil {
+		t.Fatalf("GenerateWithEncoding failed: %v", err)
+	}
+
+	timestamp, nodeID, _, err := g.InspectWithEncoding(Base58Encoding, s)
+	if err != nil {
+		t.Fatalf("InspectWithEncoding failed: %v", err)
+	}
+	if nodeID != 1 {
+		t.Errorf("expected node ID 1, got %d", nodeID)
+	}
+	if timestamp < now-1 {
+		t.Errorf("expected timestamp >= %d, got %d", now-1, timestamp)
+	}
+}
+
+func TestGenerator_GenerateULID(t *testing.T) {
+	secret := make([]byte, 16)
+	now := time.Now().Unix()
+
+	g, err := NewGenerator(42, now-1, now+3600, secret)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	u, err := g.GenerateULID()
+	if err != nil {
+		t.Fatalf("GenerateULID failed: %v", err)
+	}
+	if len(u) != 26 {
+		t.Fatalf("expected a 26-character ULID, got %q (%d chars)", u, len(u))
+	}
+
+	timestamp, nodeID, _, err := g.InspectULID(u)
+	if err != nil {
+		t.Fatalf("InspectULID failed: %v", err)
+	}
+	if nodeID != 42 {
+		t.Errorf("expected node ID 42, got %d", nodeID)
+	}
+	if timestamp < now-1 || timestamp > now+1 {
+		t.Errorf("expected timestamp close to %d, got %d", now, timestamp)
+	}
+}
@@ -0,0 +1,223 @@
+// Package lease provides cluster coordination for randflake generators.
+//
+// A randflake.Generator needs a node ID that is unique across the cluster
+// for the lifetime of a lease interval. This package defines a Coordinator
+// interface for acquiring and renewing that (node ID, lease interval) pair
+// against a shared backend, and a ManagedGenerator that keeps a
+// *randflake.Generator's lease fresh in the background.
+package lease
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"gosuda.org/randflake"
+)
+
+// defaultAcquireAttempts bounds how many node IDs a Coordinator.Acquire
+// implementation tries before giving up. The node ID space is 131072 wide;
+// sweeping it sequentially would mean tens of thousands of serial network
+// round trips once most IDs are claimed, so Acquire implementations in this
+// package sample a bounded random subset instead (see candidateNodeIDs).
+const defaultAcquireAttempts = 64
+
+// candidateNodeIDs returns up to n distinct node IDs for a Coordinator to
+// try acquiring: hint first (if it's in range), then a random sample of the
+// remaining ID space. Sampling randomly, rather than scanning sequentially
+// from 0, avoids every process in a busy cluster contending on the same
+// low-numbered IDs first.
+func candidateNodeIDs(hint int64, n int) []int64 {
+	ids := make([]int64, 0, n)
+	seen := make(map[int64]bool, n)
+
+	if hint >= 0 && hint <= randflake.RANDFLAKE_MAX_NODE {
+		ids = append(ids, hint)
+		seen[hint] = true
+	}
+
+	for len(ids) < n {
+		id := rand.Int63n(randflake.RANDFLAKE_MAX_NODE + 1)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+var (
+	// ErrLeaseLost is returned by ManagedGenerator.Generate when the background
+	// renewal loop failed to renew the lease before it expired.
+	ErrLeaseLost = errors.New("lease: lease was lost, renewal failed or coordinator revoked the node id")
+
+	// ErrAcquireFailed is returned when a Coordinator cannot hand out a node ID.
+	ErrAcquireFailed = errors.New("lease: failed to acquire a node id")
+
+	// ErrClosed is returned by ManagedGenerator methods after Close has been called.
+	ErrClosed = errors.New("lease: managed generator is closed")
+)
+
+// Coordinator hands out and renews a unique node ID and lease interval for a
+// single randflake.Generator. Implementations must ensure that a given node
+// ID is never held by two callers at the same time.
+type Coordinator interface {
+	// Acquire obtains a node ID in [0, randflake.RANDFLAKE_MAX_NODE] along with
+	// the lease interval it is valid for. hint is a preferred node ID (e.g. the
+	// last one this process held); implementations may ignore it.
+	Acquire(ctx context.Context, hint int64) (nodeID int64, leaseStart int64, leaseEnd int64, err error)
+
+	// Renew extends the previously acquired lease and returns its new end time.
+	// Renew must fail if the caller no longer holds the node ID (e.g. the lease
+	// expired and was claimed by another process).
+	Renew(ctx context.Context) (leaseEnd int64, err error)
+
+	// Release gives up the node ID so another process can acquire it immediately.
+	Release(ctx context.Context) error
+}
+
+// ManagedGenerator wraps a *randflake.Generator and keeps its lease renewed by
+// running a background goroutine against a Coordinator.
+type ManagedGenerator struct {
+	coord       Coordinator
+	renewBefore time.Duration
+
+	mu     sync.RWMutex
+	gen    *randflake.Generator
+	lost   error
+	closed bool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewManagedGenerator acquires a node ID and lease from coord, builds a
+// randflake.Generator around it, and starts a background goroutine that
+// renews the lease renewBefore its expiry. hint is passed through to
+// Coordinator.Acquire. secret is the randflake encryption secret (must be 16
+// bytes long, see randflake.NewGenerator).
+func NewManagedGenerator(ctx context.Context, coord Coordinator, hint int64, secret []byte, renewBefore time.Duration) (*ManagedGenerator, error) {
+	nodeID, leaseStart, leaseEnd, err := coord.Acquire(ctx, hint)
+	if err != nil {
+		return nil, err
+	}
+
+	gen, err := randflake.NewGenerator(nodeID, leaseStart, leaseEnd, secret)
+	if err != nil {
+		_ = coord.Release(ctx)
+		return nil, err
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	mg := &ManagedGenerator{
+		coord:       coord,
+		renewBefore: renewBefore,
+		gen:         gen,
+		cancel:      cancel,
+		done:        make(chan struct{}),
+	}
+
+	go mg.renewLoop(runCtx)
+
+	return mg, nil
+}
+
+func (mg *ManagedGenerator) renewLoop(ctx context.Context) {
+	defer close(mg.done)
+
+	for {
+		mg.mu.RLock()
+		leaseEnd := mg.gen.LeaseEnd()
+		leaseStart := mg.gen.LeaseStart()
+		mg.mu.RUnlock()
+
+		wait := time.Until(time.Unix(leaseEnd, 0).Add(-mg.renewBefore))
+		if wait < 0 {
+			wait = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		newEnd, err := mg.coord.Renew(ctx)
+		if err != nil {
+			mg.fail(err)
+			return
+		}
+
+		mg.mu.Lock()
+		mg.gen.UpdateLease(leaseStart, newEnd)
+		mg.mu.Unlock()
+	}
+}
+
+func (mg *ManagedGenerator) fail(err error) {
+	mg.mu.Lock()
+	defer mg.mu.Unlock()
+	if mg.lost == nil {
+		mg.lost = err
+	}
+}
+
+// Generate generates a unique, encrypted ID. It returns ErrLeaseLost if the
+// background renewal loop failed to keep the lease alive, or ErrClosed if the
+// managed generator has been closed.
+func (mg *ManagedGenerator) Generate() (int64, error) {
+	mg.mu.RLock()
+	defer mg.mu.RUnlock()
+
+	if mg.closed {
+		return 0, ErrClosed
+	}
+	if mg.lost != nil {
+		return 0, ErrLeaseLost
+	}
+
+	return mg.gen.Generate()
+}
+
+// GenerateString generates a unique, encrypted ID and returns it as a string.
+func (mg *ManagedGenerator) GenerateString() (string, error) {
+	mg.mu.RLock()
+	defer mg.mu.RUnlock()
+
+	if mg.closed {
+		return "", ErrClosed
+	}
+	if mg.lost != nil {
+		return "", ErrLeaseLost
+	}
+
+	return mg.gen.GenerateString()
+}
+
+// NodeID returns the node ID currently held by the managed generator.
+func (mg *ManagedGenerator) NodeID() int64 {
+	mg.mu.RLock()
+	defer mg.mu.RUnlock()
+	return mg.gen.NodeID()
+}
+
+// Close stops the background renewal goroutine and releases the node ID back
+// to the coordinator.
+func (mg *ManagedGenerator) Close() error {
+	mg.mu.Lock()
+	if mg.closed {
+		mg.mu.Unlock()
+		return nil
+	}
+	mg.closed = true
+	mg.mu.Unlock()
+
+	mg.cancel()
+	<-mg.done
+
+	return mg.coord.Release(context.Background())
+}
@@ -0,0 +1,133 @@
+package lease
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubCoordinator is an in-memory Coordinator for exercising ManagedGenerator
+// without a real etcd/Redis backend.
+type stubCoordinator struct {
+	mu sync.Mutex
+
+	nodeID     int64
+	leaseStart int64
+	leaseEnd   int64
+	renewErr   error
+
+	renewCount int
+	released   bool
+}
+
+func (c *stubCoordinator) Acquire(ctx context.Context, hint int64) (int64, int64, int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.nodeID, c.leaseStart, c.leaseEnd, nil
+}
+
+func (c *stubCoordinator) Renew(ctx context.Context) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.renewCount++
+	if c.renewErr != nil {
+		return 0, c.renewErr
+	}
+	c.leaseEnd += 10
+	return c.leaseEnd, nil
+}
+
+func (c *stubCoordinator) Release(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.released = true
+	return nil
+}
+
+func (c *stubCoordinator) renews() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.renewCount
+}
+
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("condition not met within %s", timeout)
+}
+
+func TestManagedGenerator_RenewsBeforeExpiry(t *testing.T) {
+	now := time.Now().Unix()
+	secret := make([]byte, 16)
+
+	coord := &stubCoordinator{nodeID: 1, leaseStart: now, leaseEnd: now + 1}
+
+	mg, err := NewManagedGenerator(context.Background(), coord, 1, secret, 900*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewManagedGenerator failed: %v", err)
+	}
+	defer mg.Close()
+
+	waitFor(t, 2*time.Second, func() bool { return coord.renews() >= 1 })
+
+	if _, err := mg.Generate(); err != nil {
+		t.Errorf("Generate() after successful renewal failed: %v", err)
+	}
+}
+
+func TestManagedGenerator_RenewalFailureLosesLease(t *testing.T) {
+	now := time.Now().Unix()
+	secret := make([]byte, 16)
+
+	coord := &stubCoordinator{nodeID: 1, leaseStart: now, leaseEnd: now + 1, renewErr: errors.New("coordinator unavailable")}
+
+	mg, err := NewManagedGenerator(context.Background(), coord, 1, secret, 900*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewManagedGenerator failed: %v", err)
+	}
+	defer mg.Close()
+
+	waitFor(t, 2*time.Second, func() bool {
+		_, err := mg.Generate()
+		return err == ErrLeaseLost
+	})
+}
+
+func TestManagedGenerator_CloseReleasesAndStopsGenerate(t *testing.T) {
+	now := time.Now().Unix()
+	secret := make([]byte, 16)
+
+	coord := &stubCoordinator{nodeID: 1, leaseStart: now, leaseEnd: now + 3600}
+
+	mg, err := NewManagedGenerator(context.Background(), coord, 1, secret, time.Second)
+	if err != nil {
+		t.Fatalf("NewManagedGenerator failed: %v", err)
+	}
+
+	if _, err := mg.Generate(); err != nil {
+		t.Fatalf("Generate() before Close failed: %v", err)
+	}
+
+	if err := mg.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	coord.mu.Lock()
+	released := coord.released
+	coord.mu.Unlock()
+	if !released {
+		t.Error("expected Close to release the coordinator's node ID")
+	}
+
+	if _, err := mg.Generate(); err != ErrClosed {
+		t.Errorf("Generate() after Close = %v, want ErrClosed", err)
+	}
+}
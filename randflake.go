@@ -92,6 +92,21 @@ func NewGenerator(nodeID int64, leaseStart int64, leaseEnd int64, secret []byte)
 	return &g, nil
 }
 
+// NodeID returns the node ID of the generator.
+func (g *Generator) NodeID() int64 {
+	return g.nodeID
+}
+
+// LeaseStart returns the start time of the generator's current lease, in seconds since the epoch.
+func (g *Generator) LeaseStart() int64 {
+	return g.leaseStart
+}
+
+// LeaseEnd returns the end time of the generator's current lease, in seconds since the epoch.
+func (g *Generator) LeaseEnd() int64 {
+	return g.leaseEnd.Load()
+}
+
 // UpdateLease updates the lease end time and returns true if the lease was updated.
 //
 // the leaseStart must equal to the leaseStart of the generator.
@@ -213,19 +228,26 @@ func (g *Generator) InspectString(id string) (timestamp int64, nodeID int64, seq
 const b32hexchars = "0123456789abcdefghijklmnopqrstuv"
 
 func base32hexencode(num uint64) string {
+	var buf [13]byte
+	return base32hexencodeInto(buf[:], num)
+}
+
+// base32hexencodeInto is base32hexencode using a caller-supplied 13-byte
+// scratch buffer, so batch callers can encode many IDs without allocating a
+// new buffer per ID.
+func base32hexencodeInto(buf []byte, num uint64) string {
 	if num == 0 {
 		return "0"
 	}
 
-	var encoded [13]byte
-	idx := 12
+	idx := len(buf) - 1
 	for num > 0 {
-		encoded[idx] = b32hexchars[num&0x1f]
+		buf[idx] = b32hexchars[num&0x1f]
 		num >>= 5
 		idx--
 	}
 
-	return string(encoded[idx+1:])
+	return string(buf[idx+1:])
 }
 
 func base32hexdecode(s string) (uint64, error) {
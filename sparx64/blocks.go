@@ -0,0 +1,46 @@
+package sparx64
+
+import "errors"
+
+// ErrInvalidBlockLength is returned by EncryptBlocks/DecryptBlocks when src
+// is empty, not a multiple of the block size (8), or longer than dst.
+var ErrInvalidBlockLength = errors.New("sparx64: length must be a non-zero multiple of the block size (8) and dst must be at least as long as src")
+
+// EncryptBlocks encrypts len(src)/BlockSize() independent blocks from src
+// into dst, one block at a time.
+//
+// This is a plain scalar loop that calls Encrypt once per block; there is no
+// SIMD/assembly kernel and no CPU-feature dispatch. Generator.GenerateBatch
+// uses it to encrypt a whole batch of IDs with one call. len(src) must be a
+// non-zero multiple of 8; dst must be at least as long as src.
+func (s *Sparx64) EncryptBlocks(dst, src []byte) error {
+	n, err := checkBlocks(dst, src)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < n; i++ {
+		s.Encrypt(dst[i*8:i*8+8], src[i*8:i*8+8])
+	}
+	return nil
+}
+
+// DecryptBlocks is the decrypting counterpart to EncryptBlocks.
+func (s *Sparx64) DecryptBlocks(dst, src []byte) error {
+	n, err := checkBlocks(dst, src)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < n; i++ {
+		s.Decrypt(dst[i*8:i*8+8], src[i*8:i*8+8])
+	}
+	return nil
+}
+
+func checkBlocks(dst, src []byte) (int, error) {
+	if len(src) == 0 || len(src)%8 != 0 || len(dst) < len(src) {
+		return 0, ErrInvalidBlockLength
+	}
+	return len(src) / 8, nil
+}
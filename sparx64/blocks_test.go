@@ -0,0 +1,60 @@
+package sparx64
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+// TestSparx64EncryptBlocksMatchesScalar cross-checks EncryptBlocks/
+// DecryptBlocks against the scalar Encrypt/Decrypt reference, for
+// randomized, multi-block inputs.
+func TestSparx64EncryptBlocksMatchesScalar(t *testing.T) {
+	key := make([]byte, 16)
+	rand.Read(key)
+	s := NewSparx64(key)
+
+	for _, blocks := range []int{1, 2, 7, 8, 9, 16, 33} {
+		plaintext := make([]byte, blocks*8)
+		rand.Read(plaintext)
+
+		want := make([]byte, len(plaintext))
+		for i := 0; i < blocks; i++ {
+			s.Encrypt(want[i*8:i*8+8], plaintext[i*8:i*8+8])
+		}
+
+		got := make([]byte, len(plaintext))
+		if err := s.EncryptBlocks(got, plaintext); err != nil {
+			t.Fatalf("EncryptBlocks(%d blocks) failed: %v", blocks, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("EncryptBlocks(%d blocks) = %x, want %x", blocks, got, want)
+		}
+
+		roundTrip := make([]byte, len(plaintext))
+		if err := s.DecryptBlocks(roundTrip, got); err != nil {
+			t.Fatalf("DecryptBlocks(%d blocks) failed: %v", blocks, err)
+		}
+		if !bytes.Equal(roundTrip, plaintext) {
+			t.Errorf("DecryptBlocks(%d blocks) = %x, want %x", blocks, roundTrip, plaintext)
+		}
+	}
+}
+
+func TestSparx64EncryptBlocksInvalidLength(t *testing.T) {
+	key := make([]byte, 16)
+	s := NewSparx64(key)
+
+	cases := [][]byte{
+		{},
+		make([]byte, 7),
+		make([]byte, 9),
+	}
+
+	for _, src := range cases {
+		dst := make([]byte, len(src))
+		if err := s.EncryptBlocks(dst, src); err != ErrInvalidBlockLength {
+			t.Errorf("EncryptBlocks(%d bytes) error = %v, want ErrInvalidBlockLength", len(src), err)
+		}
+	}
+}
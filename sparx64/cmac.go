@@ -0,0 +1,93 @@
+package sparx64
+
+import "crypto/cipher"
+
+// cmac computes the OMAC1/CMAC of a message under block, per NIST SP 800-38B,
+// generalized to block's native block size rather than the usual 16 bytes
+// (Sparx64's block size is 8 bytes). It is used internally by NewEAX.
+type cmac struct {
+	block cipher.Block
+	k1    []byte
+	k2    []byte
+}
+
+func newCMAC(block cipher.Block) *cmac {
+	bs := block.BlockSize()
+
+	zero := make([]byte, bs)
+	l := make([]byte, bs)
+	block.Encrypt(l, zero)
+
+	k1 := gfDouble(l)
+	k2 := gfDouble(k1)
+
+	return &cmac{block: block, k1: k1, k2: k2}
+}
+
+// gfDouble multiplies b, interpreted as an element of GF(2^(8*len(b))), by
+// the polynomial x, reducing modulo the field's irreducible polynomial. This
+// is the subkey-derivation doubling step from SP 800-38B; Rb is 0x87 for
+// 16-byte (128-bit) blocks and 0x1b for 8-byte (64-bit) blocks.
+func gfDouble(b []byte) []byte {
+	n := len(b)
+	out := make([]byte, n)
+
+	var carry byte
+	for i := n - 1; i >= 0; i-- {
+		out[i] = (b[i] << 1) | carry
+		carry = b[i] >> 7
+	}
+
+	if carry != 0 {
+		rb := byte(0x87)
+		if n == 8 {
+			rb = 0x1b
+		}
+		out[n-1] ^= rb
+	}
+
+	return out
+}
+
+func xorBytes(dst, a, b []byte) {
+	for i := range dst {
+		dst[i] = a[i] ^ b[i]
+	}
+}
+
+func (m *cmac) sum(msg []byte) []byte {
+	bs := m.block.BlockSize()
+	mac := make([]byte, bs)
+
+	if len(msg) == 0 {
+		last := make([]byte, bs)
+		last[0] = 0x80
+		xorBytes(last, last, m.k2)
+		m.block.Encrypt(mac, last)
+		return mac
+	}
+
+	nBlocks := (len(msg) + bs - 1) / bs
+	complete := len(msg)%bs == 0
+
+	for i := 0; i < nBlocks-1; i++ {
+		xorBytes(mac, mac, msg[i*bs:(i+1)*bs])
+		m.block.Encrypt(mac, mac)
+	}
+
+	tail := msg[(nBlocks-1)*bs:]
+	last := make([]byte, bs)
+	if complete {
+		copy(last, tail)
+		xorBytes(last, last, m.k1)
+	} else {
+		copy(last, tail)
+		last[len(tail)] = 0x80
+		xorBytes(last, last, m.k2)
+	}
+
+	xorBytes(mac, mac, last)
+	m.block.Encrypt(mac, mac)
+
+	return mac
+}
@@ -0,0 +1,114 @@
+package sparx64
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"errors"
+)
+
+var (
+	errGCMUnsupported = errors.New("sparx64: GCM requires a 16-byte block cipher, Sparx64 has an 8-byte block; use NewEAX instead")
+	errEAXNonceSize   = errors.New("sparx64: eax nonce must be block-size (8) bytes")
+	errEAXShort       = errors.New("sparx64: eax ciphertext shorter than tag")
+	errEAXAuth        = errors.New("sparx64: eax authentication failed")
+)
+
+// eax implements the EAX AEAD mode (Bellare, Rogaway, Wagner) over an
+// arbitrary block cipher: CTR mode for confidentiality and a CMAC-derived
+// tag binding the nonce, associated data, and ciphertext together. Unlike
+// GCM, EAX places no constraint on the underlying block size, which is why
+// it is used for Sparx64's 8-byte block.
+type eax struct {
+	block   *Sparx64
+	cmac    *cmac
+	tagSize int
+}
+
+// NewEAX wraps block in EAX mode. The nonce size and tag size both equal
+// block.BlockSize() (8 bytes).
+func NewEAX(block *Sparx64) (cipher.AEAD, error) {
+	return &eax{block: block, cmac: newCMAC(block), tagSize: block.BlockSize()}, nil
+}
+
+func (e *eax) NonceSize() int { return e.block.BlockSize() }
+func (e *eax) Overhead() int  { return e.tagSize }
+
+// omac computes OMAC_K^t(msg) = CMAC_K([t] || msg), where [t] is t encoded as
+// a single block-size-zero-padded byte, per the EAX construction.
+func (e *eax) omac(t byte, msg []byte) []byte {
+	bs := e.block.BlockSize()
+	buf := make([]byte, bs+len(msg))
+	buf[bs-1] = t
+	copy(buf[bs:], msg)
+	return e.cmac.sum(buf)
+}
+
+func (e *eax) tag(nonceMAC, header, ciphertext []byte) []byte {
+	h := e.omac(1, header)
+	c := e.omac(2, ciphertext)
+
+	tag := make([]byte, e.tagSize)
+	for i := range tag {
+		tag[i] = nonceMAC[i] ^ h[i] ^ c[i]
+	}
+	return tag
+}
+
+func (e *eax) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != e.NonceSize() {
+		panic(errEAXNonceSize)
+	}
+
+	n := e.omac(0, nonce)
+
+	ciphertext := make([]byte, len(plaintext))
+	cipher.NewCTR(e.block, n).XORKeyStream(ciphertext, plaintext)
+
+	tag := e.tag(n, additionalData, ciphertext)
+
+	ret, out := sliceForAppend(dst, len(ciphertext)+e.tagSize)
+	copy(out, ciphertext)
+	copy(out[len(ciphertext):], tag)
+	return ret
+}
+
+func (e *eax) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != e.NonceSize() {
+		return nil, errEAXNonceSize
+	}
+	if len(ciphertext) < e.tagSize {
+		return nil, errEAXShort
+	}
+
+	ct := ciphertext[:len(ciphertext)-e.tagSize]
+	wantTag := ciphertext[len(ciphertext)-e.tagSize:]
+
+	n := e.omac(0, nonce)
+	gotTag := e.tag(n, additionalData, ct)
+
+	if subtle.ConstantTimeCompare(gotTag, wantTag) != 1 {
+		return nil, errEAXAuth
+	}
+
+	plaintext := make([]byte, len(ct))
+	cipher.NewCTR(e.block, n).XORKeyStream(plaintext, ct)
+
+	ret, out := sliceForAppend(dst, len(plaintext))
+	copy(out, plaintext)
+	return ret, nil
+}
+
+// sliceForAppend extends in by n bytes, reusing its backing array when
+// there's room (mirrors the helper of the same name in crypto/cipher/gcm.go).
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return
+}
+
+var _ cipher.AEAD = (*eax)(nil)
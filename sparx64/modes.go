@@ -0,0 +1,29 @@
+package sparx64
+
+import "crypto/cipher"
+
+// NewCTR returns a Stream that encrypts or decrypts using block in CTR mode.
+// iv must be exactly block.BlockSize() (8) bytes long.
+func NewCTR(block *Sparx64, iv []byte) cipher.Stream {
+	return cipher.NewCTR(block, iv)
+}
+
+// NewCBCEncrypter returns a BlockMode that encrypts using block in CBC mode.
+// iv must be exactly block.BlockSize() (8) bytes long.
+func NewCBCEncrypter(block *Sparx64, iv []byte) cipher.BlockMode {
+	return cipher.NewCBCEncrypter(block, iv)
+}
+
+// NewCBCDecrypter returns a BlockMode that decrypts using block in CBC mode.
+// iv must be exactly block.BlockSize() (8) bytes long.
+func NewCBCDecrypter(block *Sparx64, iv []byte) cipher.BlockMode {
+	return cipher.NewCBCDecrypter(block, iv)
+}
+
+// NewGCM always fails: crypto/cipher's GCM (and NewGCMWithNonceSize) require
+// a 16-byte block cipher for their GHASH construction, but Sparx64 has an
+// 8-byte block. Use NewEAX instead, which is defined generically over the
+// underlying block size.
+func NewGCM(block *Sparx64) (cipher.AEAD, error) {
+	return nil, errGCMUnsupported
+}
@@ -0,0 +1,96 @@
+package sparx64
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestSparx64CTRRoundTrip(t *testing.T) {
+	key := make([]byte, 16)
+	rand.Read(key)
+	iv := make([]byte, 8)
+	rand.Read(iv)
+
+	plaintext := []byte("sparx64 stream cipher round trip test payload!!")
+
+	s := NewSparx64(key)
+
+	ciphertext := make([]byte, len(plaintext))
+	NewCTR(s, iv).XORKeyStream(ciphertext, plaintext)
+
+	decrypted := make([]byte, len(plaintext))
+	NewCTR(s, iv).XORKeyStream(decrypted, ciphertext)
+
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Errorf("CTR round trip failed. Expected %x, got %x", plaintext, decrypted)
+	}
+}
+
+func TestSparx64CBCRoundTrip(t *testing.T) {
+	key := make([]byte, 16)
+	rand.Read(key)
+	iv := make([]byte, 8)
+	rand.Read(iv)
+
+	plaintext := make([]byte, 16)
+	rand.Read(plaintext)
+
+	s := NewSparx64(key)
+
+	ciphertext := make([]byte, len(plaintext))
+	NewCBCEncrypter(s, iv).CryptBlocks(ciphertext, plaintext)
+
+	decrypted := make([]byte, len(plaintext))
+	NewCBCDecrypter(s, iv).CryptBlocks(decrypted, ciphertext)
+
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Errorf("CBC round trip failed. Expected %x, got %x", plaintext, decrypted)
+	}
+}
+
+func TestSparx64EAXRoundTrip(t *testing.T) {
+	key := make([]byte, 16)
+	rand.Read(key)
+
+	s := NewSparx64(key)
+	aead, err := NewEAX(s)
+	if err != nil {
+		t.Fatalf("NewEAX failed: %v", err)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	rand.Read(nonce)
+
+	plaintext := []byte("randflake node lease renewal payload")
+	additionalData := []byte("node-id:42")
+
+	sealed := aead.Seal(nil, nonce, plaintext, additionalData)
+
+	opened, err := aead.Open(nil, nonce, sealed, additionalData)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if !bytes.Equal(opened, plaintext) {
+		t.Errorf("EAX round trip failed. Expected %q, got %q", plaintext, opened)
+	}
+
+	tampered := append([]byte(nil), sealed...)
+	tampered[0] ^= 0xff
+	if _, err := aead.Open(nil, nonce, tampered, additionalData); err == nil {
+		t.Error("Open succeeded on tampered ciphertext, expected authentication failure")
+	}
+}
+
+func TestSparx64ConstantTimeCompare(t *testing.T) {
+	a := []byte{0x01, 0x02, 0x03}
+	b := []byte{0x01, 0x02, 0x03}
+	c := []byte{0x01, 0x02, 0x04}
+
+	if !ConstantTimeCompare(a, b) {
+		t.Error("expected equal byte slices to compare equal")
+	}
+	if ConstantTimeCompare(a, c) {
+		t.Error("expected differing byte slices to compare unequal")
+	}
+}
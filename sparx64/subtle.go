@@ -0,0 +1,11 @@
+package sparx64
+
+import "crypto/subtle"
+
+// ConstantTimeCompare reports whether a and b are equal, comparing their
+// contents in constant time. Intended for comparing secret keys and
+// authentication tags, where a timing side channel could otherwise leak
+// information about a value still being validated.
+func ConstantTimeCompare(a, b []byte) bool {
+	return len(a) == len(b) && subtle.ConstantTimeCompare(a, b) == 1
+}
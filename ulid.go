@@ -0,0 +1,114 @@
+package randflake
+
+import (
+	"encoding/binary"
+	"strings"
+)
+
+// GenerateULID generates a unique ID and returns it as a 26-character,
+// Crockford Base32-encoded, ULID-style string: the high 64 bits are the
+// generator's raw Unix timestamp in clear, so external systems can sort IDs
+// lexicographically by time, while the low 64 bits are the normal encrypted
+// randflake ID, so the node ID and sequence stay hidden.
+func (g *Generator) GenerateULID() (string, error) {
+	raw, err := g.newRAW()
+	if err != nil {
+		return "", err
+	}
+
+	timestamp := (raw >> (RANDFLAKE_NODE_BITS + RANDFLAKE_SEQUENCE_BITS)) + RANDFLAKE_EPOCH_OFFSET
+
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(raw))
+	g.sbox.Encrypt(b[:], b[:])
+	id := binary.LittleEndian.Uint64(b[:])
+
+	return ulidEncode(uint64(timestamp), id), nil
+}
+
+// InspectULID decodes a string produced by GenerateULID and inspects the
+// encrypted ID embedded in its low 64 bits.
+func (g *Generator) InspectULID(s string) (timestamp int64, nodeID int64, sequence int64, err error) {
+	_, id, err := ulidDecode(s)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return g.Inspect(int64(id))
+}
+
+// ulidEncode packs ts and id into 16 bytes, big-endian, and encodes them
+// with the fixed 26-character Crockford Base32 layout used by ULID.
+func ulidEncode(ts, id uint64) string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[0:8], ts)
+	binary.BigEndian.PutUint64(b[8:16], id)
+
+	var dst [26]byte
+	dst[0] = crockfordAlphabet[(b[0]&224)>>5]
+	dst[1] = crockfordAlphabet[b[0]&31]
+	dst[2] = crockfordAlphabet[(b[1]&248)>>3]
+	dst[3] = crockfordAlphabet[((b[1]&7)<<2)|((b[2]&192)>>6)]
+	dst[4] = crockfordAlphabet[(b[2]&62)>>1]
+	dst[5] = crockfordAlphabet[((b[2]&1)<<4)|((b[3]&240)>>4)]
+	dst[6] = crockfordAlphabet[((b[3]&15)<<1)|((b[4]&128)>>7)]
+	dst[7] = crockfordAlphabet[(b[4]&124)>>2]
+	dst[8] = crockfordAlphabet[((b[4]&3)<<3)|((b[5]&224)>>5)]
+	dst[9] = crockfordAlphabet[b[5]&31]
+	dst[10] = crockfordAlphabet[(b[6]&248)>>3]
+	dst[11] = crockfordAlphabet[((b[6]&7)<<2)|((b[7]&192)>>6)]
+	dst[12] = crockfordAlphabet[(b[7]&62)>>1]
+	dst[13] = crockfordAlphabet[((b[7]&1)<<4)|((b[8]&240)>>4)]
+	dst[14] = crockfordAlphabet[((b[8]&15)<<1)|((b[9]&128)>>7)]
+	dst[15] = crockfordAlphabet[(b[9]&124)>>2]
+	dst[16] = crockfordAlphabet[((b[9]&3)<<3)|((b[10]&224)>>5)]
+	dst[17] = crockfordAlphabet[b[10]&31]
+	dst[18] = crockfordAlphabet[(b[11]&248)>>3]
+	dst[19] = crockfordAlphabet[((b[11]&7)<<2)|((b[12]&192)>>6)]
+	dst[20] = crockfordAlphabet[(b[12]&62)>>1]
+	dst[21] = crockfordAlphabet[((b[12]&1)<<4)|((b[13]&240)>>4)]
+	dst[22] = crockfordAlphabet[((b[13]&15)<<1)|((b[14]&128)>>7)]
+	dst[23] = crockfordAlphabet[(b[14]&124)>>2]
+	dst[24] = crockfordAlphabet[((b[14]&3)<<3)|((b[15]&224)>>5)]
+	dst[25] = crockfordAlphabet[b[15]&31]
+
+	return string(dst[:])
+}
+
+// ulidDecode is the inverse of ulidEncode.
+func ulidDecode(s string) (ts uint64, id uint64, err error) {
+	if len(s) != 26 {
+		return 0, 0, ErrInvalidID
+	}
+	s = crockfordNormalize(s)
+
+	var v [26]byte
+	for i := 0; i < 26; i++ {
+		idx := strings.IndexByte(crockfordAlphabet, s[i])
+		if idx < 0 {
+			return 0, 0, ErrInvalidID
+		}
+		v[i] = byte(idx)
+	}
+
+	var b [16]byte
+	b[0] = (v[0] << 5) | v[1]
+	b[1] = (v[2] << 3) | (v[3] >> 2)
+	b[2] = (v[3] << 6) | (v[4] << 1) | (v[5] >> 4)
+	b[3] = (v[5] << 4) | (v[6] >> 1)
+	b[4] = (v[6] << 7) | (v[7] << 2) | (v[8] >> 3)
+	b[5] = (v[8] << 5) | v[9]
+	b[6] = (v[10] << 3) | (v[11] >> 2)
+	b[7] = (v[11] << 6) | (v[12] << 1) | (v[13] >> 4)
+	b[8] = (v[13] << 4) | (v[14] >> 1)
+	b[9] = (v[14] << 7) | (v[15] << 2) | (v[16] >> 3)
+	b[10] = (v[16] << 5) | v[17]
+	b[11] = (v[18] << 3) | (v[19] >> 2)
+	b[12] = (v[19] << 6) | (v[20] << 1) | (v[21] >> 4)
+	b[13] = (v[21] << 4) | (v[22] >> 1)
+	b[14] = (v[22] << 7) | (v[23] << 2) | (v[24] >> 3)
+	b[15] = (v[24] << 5) | v[25]
+
+	ts = binary.BigEndian.Uint64(b[0:8])
+	id = binary.BigEndian.Uint64(b[8:16])
+	return ts, id, nil
+}